@@ -0,0 +1,161 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+var (
+	// Sadly this is missing from crypto/ecdsa compared to crypto/rsa
+	ErrECDSAVerification = errors.New("crypto/ecdsa: verification error")
+)
+
+// SigningMethodECDSA implements the ECDSA family of signing methods.
+// Expects *ecdsa.PrivateKey or a crypto.Signer backed by an ECDSA key for
+// signing, and *ecdsa.PublicKey for verification.
+type SigningMethodECDSA struct {
+	Name      string
+	Hash      crypto.Hash
+	KeySize   int
+	CurveBits int
+}
+
+// Specific instances for EC256 and company
+var (
+	SigningMethodES256 *SigningMethodECDSA
+	SigningMethodES384 *SigningMethodECDSA
+	SigningMethodES512 *SigningMethodECDSA
+)
+
+func init() {
+	// ES256
+	SigningMethodES256 = &SigningMethodECDSA{"ES256", crypto.SHA256, 32, 256}
+	RegisterSigningMethod(SigningMethodES256.Alg(), func() SigningMethod {
+		return SigningMethodES256
+	})
+
+	// ES384
+	SigningMethodES384 = &SigningMethodECDSA{"ES384", crypto.SHA384, 48, 384}
+	RegisterSigningMethod(SigningMethodES384.Alg(), func() SigningMethod {
+		return SigningMethodES384
+	})
+
+	// ES512
+	SigningMethodES512 = &SigningMethodECDSA{"ES512", crypto.SHA512, 66, 521}
+	RegisterSigningMethod(SigningMethodES512.Alg(), func() SigningMethod {
+		return SigningMethodES512
+	})
+}
+
+func (m *SigningMethodECDSA) Alg() string {
+	return m.Name
+}
+
+// Verify implements token verification for the SigningMethod. For this
+// verify method, key must be an *ecdsa.PublicKey struct
+func (m *SigningMethodECDSA) Verify(signingString string, sig []byte, key interface{}) error {
+	var ecdsaKey *ecdsa.PublicKey
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		ecdsaKey = k
+	default:
+		return newError("ECDSA verify expects *ecdsa.PublicKey", ErrInvalidKeyType)
+	}
+
+	if len(sig) != 2*m.KeySize {
+		return ErrECDSAVerification
+	}
+
+	r := big.NewInt(0).SetBytes(sig[:m.KeySize])
+	s := big.NewInt(0).SetBytes(sig[m.KeySize:])
+
+	if !m.Hash.Available() {
+		return ErrHashUnavailable
+	}
+	hasher := m.Hash.New()
+	hasher.Write([]byte(signingString))
+
+	if ecdsa.Verify(ecdsaKey, hasher.Sum(nil), r, s) {
+		return nil
+	}
+
+	return ErrECDSAVerification
+}
+
+// Sign implements token signing for the SigningMethod. For this signing
+// method, key must be either an *ecdsa.PrivateKey or a crypto.Signer
+// wrapping one; see SignerKey. A crypto.Signer returns its signature in
+// ASN.1 DER form, which is unwrapped here into the fixed-width R||S form
+// used by JWS.
+func (m *SigningMethodECDSA) Sign(signingString string, key interface{}) ([]byte, error) {
+	if !m.Hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+	hasher := m.Hash.New()
+	hasher.Write([]byte(signingString))
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		curveBits := k.Curve.Params().BitSize
+		if m.CurveBits != curveBits {
+			return nil, ErrInvalidKey
+		}
+
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		return packECDSASignature(r, s, curveBits), nil
+	case crypto.Signer:
+		ecdsaKey, ok := k.Public().(*ecdsa.PublicKey)
+		if !ok {
+			return nil, newError("ECDSA sign expects a crypto.Signer backed by an ECDSA key", ErrInvalidKeyType)
+		}
+
+		curveBits := ecdsaKey.Curve.Params().BitSize
+		if m.CurveBits != curveBits {
+			return nil, ErrInvalidKey
+		}
+
+		der, err := k.Sign(rand.Reader, digest, m.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		var asn1Sig struct {
+			R, S *big.Int
+		}
+		if _, err := asn1.Unmarshal(der, &asn1Sig); err != nil {
+			return nil, newError("failed to unmarshal ECDSA signature returned by crypto.Signer", ErrInvalidKey)
+		}
+
+		return packECDSASignature(asn1Sig.R, asn1Sig.S, curveBits), nil
+	default:
+		return nil, newError("ECDSA sign expects *ecdsa.PrivateKey or crypto.Signer", ErrInvalidKeyType)
+	}
+}
+
+// packECDSASignature serializes r and s into the fixed-width, big-endian
+// R||S form used by JWS, padding each with zeros on the left as needed.
+func packECDSASignature(r, s *big.Int, curveBits int) []byte {
+	keyBytes := curveBits / 8
+	if curveBits%8 > 0 {
+		keyBytes++
+	}
+
+	rBytes := r.Bytes()
+	rBytesPadded := make([]byte, keyBytes)
+	copy(rBytesPadded[keyBytes-len(rBytes):], rBytes)
+
+	sBytes := s.Bytes()
+	sBytesPadded := make([]byte, keyBytes)
+	copy(sBytesPadded[keyBytes-len(sBytes):], sBytes)
+
+	return append(rBytesPadded, sBytesPadded...)
+}
@@ -0,0 +1,105 @@
+package jwt_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ecdsaDetachedTestData = []struct {
+	name        string
+	tokenString string
+	payload     []byte // non-nil selects jwt.WithDetachedPayload
+	valid       bool
+}{
+	{
+		"ES256 detached payload",
+		"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9..XYBeAMAirI42usKA5oR3TLXDW_qKbpqJkHQJZvTXW4yLVOZsTGWu073bo2R-bGPSL17YBw26xySObncYEQGa2Q",
+		[]byte(`{"foo":"bar"}`),
+		true,
+	},
+	{
+		"ES256 detached payload, tampered signature",
+		"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9..XYBeAMAirI42usKA5oR3TLXDW_qKbpqJkHQJZvTXA4yLVOZsTGWu073bo2R-bGPSL17YBw26xySObncYEQGa2Q",
+		[]byte(`{"foo":"bar"}`),
+		false,
+	},
+	{
+		"ES256 detached payload, wrong payload supplied",
+		"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiJ9..XYBeAMAirI42usKA5oR3TLXDW_qKbpqJkHQJZvTXW4yLVOZsTGWu073bo2R-bGPSL17YBw26xySObncYEQGa2Q",
+		[]byte(`{"foo":"baz"}`),
+		false,
+	},
+	{
+		"ES256 b64=false, attached payload",
+		"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19.unencoded-payload-value.AXEkymCU4c9UcKWn2CuIFVtLW3zJDuzeifN2wWaHLLmy6_4ryiFi09uaDED8LEwF4sxg3-lC9JVqukd81CDUqQ",
+		nil,
+		true,
+	},
+	{
+		"ES256 b64=false, detached payload",
+		"eyJ0eXAiOiJKV1QiLCJhbGciOiJFUzI1NiIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..V82DOHUS_1_tAWNc2Qhtp0F5LYL0YvrT8nehEhMsJKHKh3P3kyj1PNUyHT0Qsn8RGDMiPoSn16DvJHjDn30tAg",
+		[]byte("another-detached-unencoded-payload"),
+		true,
+	},
+}
+
+func TestECDSADetachedAndB64FalseVerify(t *testing.T) {
+	key, _ := os.ReadFile("test/ec256-public.pem")
+	ecdsaKey, err := jwt.ParseECPublicKeyFromPEM(key)
+	if err != nil {
+		t.Fatalf("Unable to parse ECDSA public key: %v", err)
+	}
+
+	for _, data := range ecdsaDetachedTestData {
+		var opts []jwt.ParserOption
+		if data.payload != nil {
+			opts = append(opts, jwt.WithDetachedPayload(data.payload))
+		}
+
+		parser := jwt.NewParser(opts...)
+		_, err := parser.Parse(data.tokenString, func(*jwt.Token) (interface{}, error) {
+			return ecdsaKey, nil
+		})
+
+		if data.valid && err != nil {
+			t.Errorf("[%v] Error while verifying token: %v", data.name, err)
+		}
+		if !data.valid && err == nil {
+			t.Errorf("[%v] Invalid token passed validation", data.name)
+		}
+	}
+}
+
+func TestECDSASignB64False(t *testing.T) {
+	key, _ := os.ReadFile("test/ec256-private.pem")
+	ecdsaKey, err := jwt.ParseECPrivateKeyFromPEM(key)
+	if err != nil {
+		t.Fatalf("Unable to parse ECDSA private key: %v", err)
+	}
+
+	payload := []byte("unencoded-payload-value")
+	token := jwt.NewWithUnencodedPayload(jwt.SigningMethodES256, payload)
+
+	sstr, err := token.SigningString()
+	if err != nil {
+		t.Fatalf("Error generating signing string: %v", err)
+	}
+	if !strings.HasSuffix(sstr, "."+string(payload)) {
+		t.Errorf("SigningString() = %q, want it to end with the raw, unencoded payload", sstr)
+	}
+
+	signed, err := token.SignedString(ecdsaKey)
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+
+	parser := jwt.NewParser()
+	if _, err := parser.Parse(signed, func(*jwt.Token) (interface{}, error) {
+		return &ecdsaKey.PublicKey, nil
+	}); err != nil {
+		t.Errorf("Error verifying self-signed b64=false token: %v", err)
+	}
+}
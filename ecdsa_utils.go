@@ -0,0 +1,59 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+var (
+	ErrNotECPrivateKey = errors.New("key is not a valid ECDSA private key")
+	ErrNotECPublicKey  = errors.New("key is not a valid ECDSA public key")
+)
+
+// ParseECPrivateKeyFromPEM parses a PEM-encoded SEC1 or PKCS8 private key
+// and returns an *ecdsa.PrivateKey.
+func ParseECPrivateKeyFromPEM(key []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, ErrKeyMustBePEMEncoded
+	}
+
+	if pkey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return pkey, nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pkey, ok := parsedKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrNotECPrivateKey
+	}
+
+	return pkey, nil
+}
+
+// ParseECPublicKeyFromPEM parses a PEM-encoded PKIX public key and returns
+// an *ecdsa.PublicKey.
+func ParseECPublicKeyFromPEM(key []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, ErrKeyMustBePEMEncoded
+	}
+
+	parsedKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pkey, ok := parsedKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrNotECPublicKey
+	}
+
+	return pkey, nil
+}
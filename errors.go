@@ -0,0 +1,38 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidKey            = errors.New("key is invalid")
+	ErrInvalidKeyType        = errors.New("key is of invalid type")
+	ErrHashUnavailable       = errors.New("the requested hash function is unavailable")
+	ErrKeyMustBePEMEncoded   = errors.New("invalid key: key must be a PEM encoded block")
+	ErrTokenMalformed        = errors.New("token is malformed")
+	ErrTokenUnverifiable     = errors.New("token is unverifiable")
+	ErrTokenSignatureInvalid = errors.New("token signature is invalid")
+)
+
+// newError wraps err (and any of more) behind message, using fmt.Errorf's
+// %w so that errors.Is/errors.As still see through to the sentinel errors
+// above.
+func newError(message string, err error, more ...error) error {
+	var format string
+	var args []any
+	if message != "" {
+		format = "%w: %s"
+		args = []any{err, message}
+	} else {
+		format = "%w"
+		args = []any{err}
+	}
+
+	for _, e := range more {
+		format += ": %w"
+		args = append(args, e)
+	}
+
+	return fmt.Errorf(format, args...)
+}
@@ -0,0 +1,281 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrJWKUnsupportedKty = errors.New("jwt: unsupported JWK \"kty\"")
+	ErrJWKUnsupportedCrv = errors.New("jwt: unsupported JWK \"crv\"")
+)
+
+// JWK is a JSON Web Key, as defined by RFC 7517 and the key type
+// parameters of RFC 7518 §6. Only the fields needed to round-trip the EC,
+// RSA and OKP (Ed25519) key types used elsewhere in this package are
+// represented; unknown fields are ignored on parse.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+}
+
+// PublicKey returns the public key represented by j as a concrete Go
+// crypto type (*ecdsa.PublicKey, *rsa.PublicKey, or ed25519.PublicKey),
+// selected by its "kty" (and, for "EC", its "crv").
+func (j *JWK) PublicKey() (interface{}, error) {
+	switch j.Kty {
+	case "EC":
+		return j.ecdsaPublicKey()
+	case "RSA":
+		return j.rsaPublicKey()
+	case "OKP":
+		return j.ed25519PublicKey()
+	default:
+		return nil, ErrJWKUnsupportedKty
+	}
+}
+
+func curveForCrv(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, ErrJWKUnsupportedCrv
+	}
+}
+
+func crvAndAlgForCurve(curve elliptic.Curve) (crv, alg string, err error) {
+	switch curve {
+	case elliptic.P256():
+		return "P-256", "ES256", nil
+	case elliptic.P384():
+		return "P-384", "ES384", nil
+	case elliptic.P521():
+		return "P-521", "ES512", nil
+	default:
+		return "", "", ErrJWKUnsupportedCrv
+	}
+}
+
+func (j *JWK) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	curve, err := curveForCrv(j.Crv)
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(j.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(j.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}, nil
+}
+
+func (j *JWK) ecdsaPrivateKey() (*ecdsa.PrivateKey, error) {
+	pub, err := j.ecdsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := base64.RawURLEncoding.DecodeString(j.D)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).SetBytes(d),
+	}, nil
+}
+
+func (j *JWK) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(j.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(j.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// rsaPrivateKey builds an *rsa.PrivateKey from the "n"/"e"/"d" members.
+// RFC 7518 §6.3.2 also defines "p"/"q"/"dp"/"dq"/"qi" for the CRT form,
+// which this does not populate; callers that need an RSA key capable of
+// the fast CRT signing path should call Precompute themselves once p and q
+// are available, or prefer a PEM-encoded PKCS8 key via
+// ParseRSAPrivateKeyFromPEM.
+func (j *JWK) rsaPrivateKey() (*rsa.PrivateKey, error) {
+	pub, err := j.rsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := base64.RawURLEncoding.DecodeString(j.D)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).SetBytes(d),
+	}, nil
+}
+
+func (j *JWK) ed25519PublicKey() (ed25519.PublicKey, error) {
+	if j.Crv != "Ed25519" {
+		return nil, ErrJWKUnsupportedCrv
+	}
+
+	return base64.RawURLEncoding.DecodeString(j.X)
+}
+
+func (j *JWK) ed25519PrivateKey() (ed25519.PrivateKey, error) {
+	if j.Crv != "Ed25519" {
+		return nil, ErrJWKUnsupportedCrv
+	}
+
+	seed, err := base64.RawURLEncoding.DecodeString(j.D)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParseECPublicKeyFromJWK parses a single EC JWK, encoded as JSON, and
+// returns an *ecdsa.PublicKey.
+func ParseECPublicKeyFromJWK(data []byte) (*ecdsa.PublicKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "EC" {
+		return nil, ErrJWKUnsupportedKty
+	}
+
+	return jwk.ecdsaPublicKey()
+}
+
+// ParseECPrivateKeyFromJWK parses a single EC JWK, encoded as JSON, and
+// returns an *ecdsa.PrivateKey.
+func ParseECPrivateKeyFromJWK(data []byte) (*ecdsa.PrivateKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "EC" {
+		return nil, ErrJWKUnsupportedKty
+	}
+
+	return jwk.ecdsaPrivateKey()
+}
+
+// ParseRSAPublicKeyFromJWK parses a single RSA JWK, encoded as JSON, and
+// returns an *rsa.PublicKey.
+func ParseRSAPublicKeyFromJWK(data []byte) (*rsa.PublicKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "RSA" {
+		return nil, ErrJWKUnsupportedKty
+	}
+
+	return jwk.rsaPublicKey()
+}
+
+// ParseRSAPrivateKeyFromJWK parses a single RSA JWK, encoded as JSON, and
+// returns an *rsa.PrivateKey. See the caveat on rsaPrivateKey regarding the
+// CRT parameters.
+func ParseRSAPrivateKeyFromJWK(data []byte) (*rsa.PrivateKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "RSA" {
+		return nil, ErrJWKUnsupportedKty
+	}
+
+	return jwk.rsaPrivateKey()
+}
+
+// ParseEdPublicKeyFromJWK parses a single OKP (Ed25519) JWK, encoded as
+// JSON, and returns an ed25519.PublicKey.
+func ParseEdPublicKeyFromJWK(data []byte) (ed25519.PublicKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "OKP" {
+		return nil, ErrJWKUnsupportedKty
+	}
+
+	return jwk.ed25519PublicKey()
+}
+
+// ParseEdPrivateKeyFromJWK parses a single OKP (Ed25519) JWK, encoded as
+// JSON, and returns an ed25519.PrivateKey.
+func ParseEdPrivateKeyFromJWK(data []byte) (ed25519.PrivateKey, error) {
+	var jwk JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Kty != "OKP" {
+		return nil, ErrJWKUnsupportedKty
+	}
+
+	return jwk.ed25519PrivateKey()
+}
+
+// NewJWKFromECPublicKey encodes pub as a JWK with kid as its "kid" member.
+// The "crv" and "alg" members are derived from pub's curve (P-256/ES256,
+// P-384/ES384, or P-521/ES512).
+func NewJWKFromECPublicKey(pub *ecdsa.PublicKey, kid string) (*JWK, error) {
+	crv, alg, err := crvAndAlgForCurve(pub.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	size := (pub.Curve.Params().BitSize + 7) / 8
+
+	return &JWK{
+		Kty: "EC",
+		Crv: crv,
+		Alg: alg,
+		Kid: kid,
+		X:   base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size))),
+		Y:   base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size))),
+	}, nil
+}
@@ -0,0 +1,147 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultJWKSetTTL is the cache lifetime applied to a fetched JWK Set when
+// the response carries no usable Cache-Control max-age directive.
+const DefaultJWKSetTTL = 5 * time.Minute
+
+// JWKSet is a parsed JSON Web Key Set (RFC 7517 §5), optionally backed by a
+// remote jwks_uri that Fetch refreshes on a TTL honoring the response's
+// Cache-Control header. The zero value is an empty, usable set.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+
+	mu         sync.Mutex
+	httpClient *http.Client
+	url        string
+	expiresAt  time.Time
+}
+
+// NewJWKSet returns an empty JWKSet that fetches with http.DefaultClient.
+func NewJWKSet() *JWKSet {
+	return &JWKSet{httpClient: http.DefaultClient}
+}
+
+// Fetch retrieves and parses the JWK Set at url. A Fetch call for the same
+// url made again before the cache TTL elapses — derived from the
+// response's Cache-Control: max-age, or DefaultJWKSetTTL if that's absent
+// or unparseable — returns immediately without making another request.
+// This is the usual way to point jwt.Parse at an OIDC issuer's jwks_uri.
+func (s *JWKSet) Fetch(ctx context.Context, url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.url == url && time.Now().Before(s.expiresAt) {
+		return nil
+	}
+
+	client := s.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwt: fetching JWK Set from %s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var set JWKSet
+	if err := json.Unmarshal(body, &set); err != nil {
+		return err
+	}
+
+	s.Keys = set.Keys
+	s.url = url
+	s.expiresAt = time.Now().Add(cacheTTL(resp.Header.Get("Cache-Control")))
+
+	return nil
+}
+
+// cacheTTL derives a cache lifetime from a Cache-Control header value,
+// honoring max-age (including "max-age=0", which means re-fetch every
+// time, not "use the default TTL") and no-store, and falling back to
+// DefaultJWKSetTTL if the header is absent or its max-age directive can't
+// be parsed.
+func cacheTTL(cacheControl string) time.Duration {
+	ttl := DefaultJWKSetTTL
+
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+
+		if strings.EqualFold(directive, "no-store") {
+			return 0
+		}
+
+		name, value, ok := strings.Cut(directive, "=")
+		if !ok || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+
+		if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && seconds >= 0 {
+			ttl = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return ttl
+}
+
+// Key returns the key in the set with the given kid, or nil if none
+// matches.
+func (s *JWKSet) Key(kid string) *JWK {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.Keys {
+		if s.Keys[i].Kid == kid {
+			return &s.Keys[i]
+		}
+	}
+
+	return nil
+}
+
+// KeyFunc returns a Keyfunc that selects the key named by the token's
+// "kid" header from the set and returns its public key for verification.
+// This lets jwt.Parse (or Parser.Parse) be pointed directly at an OIDC
+// issuer's jwks_uri via Fetch, instead of shuffling PEM files.
+func (s *JWKSet) KeyFunc() Keyfunc {
+	return func(token *Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, newError("token has no kid header to select a JWK Set key", ErrTokenUnverifiable)
+		}
+
+		jwk := s.Key(kid)
+		if jwk == nil {
+			return nil, newError(fmt.Sprintf("no key found in JWK Set for kid %q", kid), ErrTokenUnverifiable)
+		}
+
+		return jwk.PublicKey()
+	}
+}
@@ -0,0 +1,147 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var jwkCurveTestData = []struct {
+	name  string
+	curve elliptic.Curve
+	alg   string
+}{
+	{"ES256", elliptic.P256(), "ES256"},
+	{"ES384", elliptic.P384(), "ES384"},
+	{"ES512", elliptic.P521(), "ES512"},
+}
+
+func TestJWKECRoundTrip(t *testing.T) {
+	for _, data := range jwkCurveTestData {
+		t.Run(data.name, func(t *testing.T) {
+			key, err := ecdsa.GenerateKey(data.curve, rand.Reader)
+			if err != nil {
+				t.Fatalf("Unable to generate ECDSA key: %v", err)
+			}
+
+			jwk, err := jwt.NewJWKFromECPublicKey(&key.PublicKey, "test-kid")
+			if err != nil {
+				t.Fatalf("Error marshaling public key to JWK: %v", err)
+			}
+			if jwk.Alg != data.alg {
+				t.Errorf("JWK alg = %v, want %v", jwk.Alg, data.alg)
+			}
+
+			marshaled, err := json.Marshal(jwk)
+			if err != nil {
+				t.Fatalf("Error marshaling JWK to JSON: %v", err)
+			}
+
+			parsed, err := jwt.ParseECPublicKeyFromJWK(marshaled)
+			if err != nil {
+				t.Fatalf("Error parsing JWK: %v", err)
+			}
+
+			if parsed.Curve != data.curve {
+				t.Errorf("Parsed curve = %v, want %v", parsed.Curve, data.curve)
+			}
+			if parsed.X.Cmp(key.PublicKey.X) != 0 || parsed.Y.Cmp(key.PublicKey.Y) != 0 {
+				t.Errorf("Parsed public key does not match original")
+			}
+
+			method := jwt.GetSigningMethod(data.alg)
+			toSign := "eyJhbGciOiJub25lIn0.eyJmb28iOiJiYXIifQ"
+			sig, err := method.Sign(toSign, key)
+			if err != nil {
+				t.Fatalf("Error signing with original key: %v", err)
+			}
+			if err := method.Verify(toSign, sig, parsed); err != nil {
+				t.Errorf("Signature made with original key failed to verify with JWK-round-tripped key: %v", err)
+			}
+		})
+	}
+}
+
+func TestJWKSetFetchAndKeyFunc(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate ECDSA key: %v", err)
+	}
+
+	jwk, err := jwt.NewJWKFromECPublicKey(&key.PublicKey, "kid-1")
+	if err != nil {
+		t.Fatalf("Error marshaling public key to JWK: %v", err)
+	}
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_ = json.NewEncoder(w).Encode(jwt.JWKSet{Keys: []jwt.JWK{*jwk}})
+	}))
+	defer server.Close()
+
+	set := jwt.NewJWKSet()
+	if err := set.Fetch(context.Background(), server.URL); err != nil {
+		t.Fatalf("Error fetching JWK Set: %v", err)
+	}
+	if err := set.Fetch(context.Background(), server.URL); err != nil {
+		t.Fatalf("Error re-fetching JWK Set: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the cached result to avoid a second request, server saw %d requests", requests)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, map[string]interface{}{"foo": "bar"})
+	token.Header["kid"] = "kid-1"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Error signing token: %v", err)
+	}
+
+	if _, err := jwt.NewParser().Parse(signed, set.KeyFunc()); err != nil {
+		t.Errorf("Error verifying token against JWKSet.KeyFunc: %v", err)
+	}
+}
+
+func TestJWKSetFetchMaxAgeZeroDoesNotCache(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate ECDSA key: %v", err)
+	}
+
+	jwk, err := jwt.NewJWKFromECPublicKey(&key.PublicKey, "kid-1")
+	if err != nil {
+		t.Fatalf("Error marshaling public key to JWK: %v", err)
+	}
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// max-age=0 means "revalidate every time", not "no opinion, use
+		// the default TTL" — a JWKS endpoint sends this immediately
+		// after a key rotation to force clients to stop trusting cached
+		// keys.
+		w.Header().Set("Cache-Control", "max-age=0")
+		_ = json.NewEncoder(w).Encode(jwt.JWKSet{Keys: []jwt.JWK{*jwk}})
+	}))
+	defer server.Close()
+
+	set := jwt.NewJWKSet()
+	if err := set.Fetch(context.Background(), server.URL); err != nil {
+		t.Fatalf("Error fetching JWK Set: %v", err)
+	}
+	if err := set.Fetch(context.Background(), server.URL); err != nil {
+		t.Fatalf("Error re-fetching JWK Set: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected max-age=0 to force a second request, server saw %d requests", requests)
+	}
+}
@@ -0,0 +1,215 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// SignerSpec describes one signature to produce when creating a JWS in the
+// JSON Serialization (RFC 7515 §7.2): the protected and unprotected headers
+// to include, and the SigningMethod and key used to produce it.
+type SignerSpec struct {
+	Protected   map[string]interface{}
+	Unprotected map[string]interface{}
+	Method      SigningMethod
+	Key         interface{}
+}
+
+// JSONSignature is one signature within a JWS JSON Serialization object, as
+// defined by RFC 7515 §7.2.1.
+type JSONSignature struct {
+	Protected string                 `json:"protected,omitempty"`
+	Header    map[string]interface{} `json:"header,omitempty"`
+	Signature string                 `json:"signature"`
+}
+
+// JSONToken is a parsed JWS JSON Serialization object. Both the general and
+// flattened forms (RFC 7515 §7.2.1, §7.2.2) parse into this shape; a
+// flattened input simply produces a single-element Signatures slice.
+type JSONToken struct {
+	Payload    string          `json:"payload"`
+	Signatures []JSONSignature `json:"signatures"`
+}
+
+// flattenedJSONToken is the wire shape of the flattened JSON Serialization,
+// used when there is exactly one signature.
+type flattenedJSONToken struct {
+	Payload   string                 `json:"payload"`
+	Protected string                 `json:"protected,omitempty"`
+	Header    map[string]interface{} `json:"header,omitempty"`
+	Signature string                 `json:"signature"`
+}
+
+// JSONSerializer produces JWS JSON Serialization output.
+type JSONSerializer struct {
+	// Flatten selects the flattened form (RFC 7515 §7.2.2) whenever
+	// SignMulti is called with exactly one SignerSpec. It is ignored once
+	// there is more than one spec, since the flattened form only exists
+	// for the single-signature case. Defaults to true via
+	// NewJSONSerializer.
+	Flatten bool
+}
+
+// NewJSONSerializer returns a JSONSerializer that prefers the flattened
+// form for single-signature output.
+func NewJSONSerializer() *JSONSerializer {
+	return &JSONSerializer{Flatten: true}
+}
+
+// SignMulti signs payload once per SignerSpec in specs and encodes the
+// result as a JWS JSON Serialization object. With one spec and Flatten set,
+// the flattened form is produced; otherwise the general form with a
+// signatures array is used.
+func (s *JSONSerializer) SignMulti(payload []byte, specs []SignerSpec) ([]byte, error) {
+	if len(specs) == 0 {
+		return nil, newError("SignMulti requires at least one SignerSpec", ErrInvalidKey)
+	}
+
+	payloadSeg := base64.RawURLEncoding.EncodeToString(payload)
+
+	sigs := make([]JSONSignature, len(specs))
+	for i, spec := range specs {
+		protectedSeg, sigSeg, err := signJSON(payloadSeg, spec)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = JSONSignature{
+			Protected: protectedSeg,
+			Header:    spec.Unprotected,
+			Signature: sigSeg,
+		}
+	}
+
+	if s.Flatten && len(sigs) == 1 {
+		return json.Marshal(flattenedJSONToken{
+			Payload:   payloadSeg,
+			Protected: sigs[0].Protected,
+			Header:    sigs[0].Header,
+			Signature: sigs[0].Signature,
+		})
+	}
+
+	return json.Marshal(JSONToken{Payload: payloadSeg, Signatures: sigs})
+}
+
+// signJSON produces the base64url-encoded protected header and signature
+// segments for a single SignerSpec.
+func signJSON(payloadSeg string, spec SignerSpec) (protectedSeg, sigSeg string, err error) {
+	if spec.Method == nil {
+		return "", "", newError("SignerSpec requires a SigningMethod", ErrInvalidKeyType)
+	}
+
+	protected := make(map[string]interface{}, len(spec.Protected)+1)
+	for k, v := range spec.Protected {
+		protected[k] = v
+	}
+	protected["alg"] = spec.Method.Alg()
+
+	protectedJSON, err := json.Marshal(protected)
+	if err != nil {
+		return "", "", err
+	}
+	protectedSeg = base64.RawURLEncoding.EncodeToString(protectedJSON)
+
+	sig, err := spec.Method.Sign(protectedSeg+"."+payloadSeg, spec.Key)
+	if err != nil {
+		return "", "", err
+	}
+
+	return protectedSeg, base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// JSONParser parses JWS JSON Serialization input.
+type JSONParser struct{}
+
+// NewJSONParser returns a JSONParser.
+func NewJSONParser() *JSONParser {
+	return &JSONParser{}
+}
+
+// ParseJSON parses data as a JWS JSON Serialization object, accepting
+// either the general or flattened form (RFC 7515 §7.2).
+func (p *JSONParser) ParseJSON(data []byte) (*JSONToken, error) {
+	var general JSONToken
+	if err := json.Unmarshal(data, &general); err == nil && len(general.Signatures) > 0 {
+		return &general, nil
+	}
+
+	var flat flattenedJSONToken
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, err
+	}
+	if flat.Signature == "" {
+		return nil, newError("not a valid JWS JSON Serialization object", ErrTokenMalformed)
+	}
+
+	return &JSONToken{
+		Payload: flat.Payload,
+		Signatures: []JSONSignature{{
+			Protected: flat.Protected,
+			Header:    flat.Header,
+			Signature: flat.Signature,
+		}},
+	}, nil
+}
+
+// SignatureResult is the outcome of verifying one signature within a
+// JSONToken, returned in the same order as JSONToken.Signatures.
+type SignatureResult struct {
+	// Alg is the "alg" value from the signature's protected header, read
+	// out before verification so a relying party's KeyFunc — or the
+	// caller inspecting the results — can tell which algorithm produced
+	// which result even on failure.
+	Alg   string
+	Error error
+}
+
+// DecodedPayload returns the decoded payload of the JWS JSON Serialization
+// object.
+func (t *JSONToken) DecodedPayload() ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(t.Payload)
+}
+
+// Verify checks every signature in t. keyFunc is handed each signature's
+// decoded protected header and must return the SigningMethod and key to
+// verify that signature with. The returned slice has one SignatureResult
+// per signature, letting a relying party enforce policies such as "at
+// least one ES256 signature by key A AND one EdDSA signature by key B" —
+// useful for in-toto/DSSE-style attestations and for rotating keys without
+// reissuing tokens — without this package taking a position on what that
+// policy should be.
+func (t *JSONToken) Verify(keyFunc func(protected map[string]interface{}) (SigningMethod, interface{}, error)) []SignatureResult {
+	results := make([]SignatureResult, len(t.Signatures))
+
+	for i, jsig := range t.Signatures {
+		var protected map[string]interface{}
+		if jsig.Protected != "" {
+			protectedJSON, err := base64.RawURLEncoding.DecodeString(jsig.Protected)
+			if err != nil {
+				results[i].Error = err
+				continue
+			}
+			if err := json.Unmarshal(protectedJSON, &protected); err != nil {
+				results[i].Error = err
+				continue
+			}
+		}
+		results[i].Alg, _ = protected["alg"].(string)
+
+		method, key, err := keyFunc(protected)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+
+		sig, err := base64.RawURLEncoding.DecodeString(jsig.Signature)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+
+		results[i].Error = method.Verify(jsig.Protected+"."+t.Payload, sig, key)
+	}
+
+	return results
+}
@@ -0,0 +1,115 @@
+package jwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJSONSerializationMultiSignature(t *testing.T) {
+	esKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate ECDSA key: %v", err)
+	}
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate Ed25519 key: %v", err)
+	}
+
+	payload := []byte(`{"foo":"bar"}`)
+
+	serializer := jwt.NewJSONSerializer()
+	data, err := serializer.SignMulti(payload, []jwt.SignerSpec{
+		{Protected: map[string]interface{}{"kid": "A"}, Method: jwt.SigningMethodES256, Key: esKey},
+		{Protected: map[string]interface{}{"kid": "B"}, Method: jwt.SigningMethodEdDSA, Key: edPriv},
+	})
+	if err != nil {
+		t.Fatalf("Error signing multi-signature JWS: %v", err)
+	}
+
+	parser := jwt.NewJSONParser()
+	token, err := parser.ParseJSON(data)
+	if err != nil {
+		t.Fatalf("Error parsing JWS JSON Serialization: %v", err)
+	}
+
+	if len(token.Signatures) != 2 {
+		t.Fatalf("Expected 2 signatures, got %d", len(token.Signatures))
+	}
+
+	results := token.Verify(func(protected map[string]interface{}) (jwt.SigningMethod, interface{}, error) {
+		switch protected["kid"] {
+		case "A":
+			return jwt.SigningMethodES256, &esKey.PublicKey, nil
+		case "B":
+			return jwt.SigningMethodEdDSA, edPub, nil
+		default:
+			return nil, nil, jwt.ErrInvalidKeyType
+		}
+	})
+
+	var haveES256, haveEdDSA bool
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("Signature for alg %v failed to verify: %v", result.Alg, result.Error)
+		}
+		switch result.Alg {
+		case "ES256":
+			haveES256 = true
+		case "EdDSA":
+			haveEdDSA = true
+		}
+	}
+
+	if !haveES256 || !haveEdDSA {
+		t.Errorf("Expected at least one ES256 and one EdDSA signature, got results: %+v", results)
+	}
+}
+
+func TestJSONSerializationFlattened(t *testing.T) {
+	esKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate ECDSA key: %v", err)
+	}
+
+	payload := []byte(`{"foo":"bar"}`)
+
+	serializer := jwt.NewJSONSerializer()
+	data, err := serializer.SignMulti(payload, []jwt.SignerSpec{
+		{Method: jwt.SigningMethodES256, Key: esKey},
+	})
+	if err != nil {
+		t.Fatalf("Error signing flattened JWS: %v", err)
+	}
+
+	parser := jwt.NewJSONParser()
+	token, err := parser.ParseJSON(data)
+	if err != nil {
+		t.Fatalf("Error parsing flattened JWS JSON Serialization: %v", err)
+	}
+
+	if len(token.Signatures) != 1 {
+		t.Fatalf("Expected 1 signature, got %d", len(token.Signatures))
+	}
+
+	results := token.Verify(func(protected map[string]interface{}) (jwt.SigningMethod, interface{}, error) {
+		return jwt.SigningMethodES256, &esKey.PublicKey, nil
+	})
+
+	if results[0].Error != nil {
+		t.Errorf("Flattened signature failed to verify: %v", results[0].Error)
+	}
+
+	decoded, err := token.DecodedPayload()
+	if err != nil {
+		t.Fatalf("Error decoding payload: %v", err)
+	}
+	if string(decoded) != string(payload) {
+		t.Errorf("Decoded payload = %q, want %q", decoded, payload)
+	}
+}
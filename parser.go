@@ -0,0 +1,162 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// ParserOption configures a Parser returned by NewParser.
+type ParserOption func(*Parser)
+
+// Parser parses and verifies tokens.
+type Parser struct {
+	validMethods []string
+
+	// detachedPayload is the externally supplied payload to verify a
+	// detached-payload token against, set via WithDetachedPayload. A
+	// detached-payload token carries an empty payload segment
+	// (header..signature) and relies on the relying party supplying the
+	// same payload that was signed out of band.
+	detachedPayload []byte
+}
+
+// NewParser creates a new Parser, configured by the given options.
+func NewParser(options ...ParserOption) *Parser {
+	p := &Parser{}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// WithValidMethods restricts the signing methods accepted by Parse to the
+// named algorithms.
+func WithValidMethods(methods []string) ParserOption {
+	return func(p *Parser) {
+		p.validMethods = methods
+	}
+}
+
+// WithDetachedPayload configures the Parser to verify a detached-payload
+// token — one of the form header..signature, with no payload segment —
+// against the externally supplied payload. This is the mode used by HTTP
+// message signatures and artifact signing pipelines, where the payload
+// (an HTTP body, a build artifact, ...) is transmitted separately from the
+// JWS itself.
+func WithDetachedPayload(payload []byte) ParserOption {
+	return func(p *Parser) {
+		p.detachedPayload = payload
+	}
+}
+
+// DecodeSegment decodes a JWT specific base64url encoding with padding
+// stripped.
+func (p *Parser) DecodeSegment(seg string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(seg)
+}
+
+// Parse parses, validates, and verifies a token, using keyFunc to supply
+// the key for signature verification.
+func (p *Parser) Parse(tokenString string, keyFunc Keyfunc) (*Token, error) {
+	// Split on the first and last '.' rather than strings.Split into
+	// exactly three parts: an unencoded (b64=false) payload is allowed to
+	// contain '.' characters of its own.
+	firstDot := strings.IndexByte(tokenString, '.')
+	lastDot := strings.LastIndexByte(tokenString, '.')
+	if firstDot < 0 || lastDot <= firstDot {
+		return nil, newError("token contains an invalid number of segments", ErrTokenMalformed)
+	}
+
+	headerSeg := tokenString[:firstDot]
+	payloadSeg := tokenString[firstDot+1 : lastDot]
+	sigSeg := tokenString[lastDot+1:]
+
+	headerBytes, err := p.DecodeSegment(headerSeg)
+	if err != nil {
+		return nil, newError("could not decode header", ErrTokenMalformed, err)
+	}
+
+	token := &Token{Raw: tokenString}
+	if err = json.Unmarshal(headerBytes, &token.Header); err != nil {
+		return nil, newError("could not unmarshal header", ErrTokenMalformed, err)
+	}
+
+	alg, _ := token.Header["alg"].(string)
+	if p.validMethods != nil && !p.algIsValid(alg) {
+		return nil, newError("signing method "+alg+" is not allowed", ErrTokenSignatureInvalid)
+	}
+
+	token.Method = GetSigningMethod(alg)
+	if token.Method == nil {
+		return nil, newError("signing method "+alg+" is unavailable", ErrTokenUnverifiable)
+	}
+
+	unencoded := false
+	if b64, ok := token.Header["b64"].(bool); ok {
+		unencoded = !b64
+	}
+
+	// wirePayloadSeg is what goes back into the signing string; payload is
+	// the decoded bytes used to populate token.Claims.
+	var wirePayloadSeg string
+	var payload []byte
+
+	if len(p.detachedPayload) != 0 {
+		if payloadSeg != "" {
+			return nil, newError("detached-payload token must not carry a payload segment", ErrTokenMalformed)
+		}
+		payload = p.detachedPayload
+		if unencoded {
+			wirePayloadSeg = string(payload)
+		} else {
+			wirePayloadSeg = base64.RawURLEncoding.EncodeToString(payload)
+		}
+	} else {
+		wirePayloadSeg = payloadSeg
+		if unencoded {
+			payload = []byte(payloadSeg)
+		} else {
+			if payload, err = p.DecodeSegment(payloadSeg); err != nil {
+				return nil, newError("could not decode payload", ErrTokenMalformed, err)
+			}
+		}
+	}
+
+	// An unencoded (b64=false) payload is arbitrary content, not
+	// necessarily JSON claims — e.g. an HTTP message signature or a
+	// detached artifact digest — so it's left undecoded in token.Claims.
+	if len(p.detachedPayload) == 0 && !unencoded {
+		if err = json.Unmarshal(payload, &token.Claims); err != nil {
+			return nil, newError("could not unmarshal claims", ErrTokenMalformed, err)
+		}
+	}
+
+	key, err := keyFunc(token)
+	if err != nil {
+		return nil, newError("error while executing keyfunc", ErrTokenUnverifiable, err)
+	}
+
+	sig, err := p.DecodeSegment(sigSeg)
+	if err != nil {
+		return nil, newError("could not decode signature", ErrTokenMalformed, err)
+	}
+	token.Signature = sigSeg
+
+	signingString := headerSeg + "." + wirePayloadSeg
+	if err = token.Method.Verify(signingString, sig, key); err != nil {
+		return nil, newError("signature is invalid", ErrTokenSignatureInvalid, err)
+	}
+
+	token.Valid = true
+	return token, nil
+}
+
+func (p *Parser) algIsValid(alg string) bool {
+	for _, m := range p.validMethods {
+		if m == alg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package jwt
+
+import "crypto"
+
+// SignerKey wraps an opaque crypto.Signer — such as a key held in an HSM, a
+// cloud KMS, or a Vault transit backend — so it can be passed directly as
+// the signing key to Token.SignedString or SigningMethod.Sign. The wrapped
+// Signer's Sign method is invoked with the hash algorithm (or crypto.Hash(0)
+// for Ed25519) appropriate to the signing method, and the private key
+// material itself never has to leave wherever the Signer implementation
+// keeps it.
+type SignerKey struct {
+	crypto.Signer
+}
+
+// NewSignerKey wraps signer so it can be used as a signing key with any of
+// the asymmetric SigningMethod implementations.
+func NewSignerKey(signer crypto.Signer) SignerKey {
+	return SignerKey{Signer: signer}
+}
@@ -0,0 +1,93 @@
+package jwt_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// opaqueSigner wraps a crypto.Signer but only exposes it through that
+// interface, the way a key backed by an HSM or cloud KMS would be exposed
+// to this package: SigningMethod.Sign must never type assert its way past
+// crypto.Signer down to a concrete private key type.
+type opaqueSigner struct {
+	signer crypto.Signer
+}
+
+func (s opaqueSigner) Public() crypto.PublicKey {
+	return s.signer.Public()
+}
+
+func (s opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.signer.Sign(rand, digest, opts)
+}
+
+func TestSignerKeyECDSARoundTrip(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate ECDSA key: %v", err)
+	}
+
+	signer := jwt.NewSignerKey(opaqueSigner{signer: ecdsaKey})
+
+	method := jwt.GetSigningMethod("ES256")
+	toSign := "eyJhbGciOiJFUzI1NiIsInR5cCI6IkpXVCJ9.eyJmb28iOiJiYXIifQ"
+
+	sig, err := method.Sign(toSign, signer)
+	if err != nil {
+		t.Fatalf("Error signing with opaque crypto.Signer: %v", err)
+	}
+
+	if err := method.Verify(toSign, sig, &ecdsaKey.PublicKey); err != nil {
+		t.Errorf("Signature produced via crypto.Signer failed to verify: %v", err)
+	}
+}
+
+func TestSignerKeyRSARoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Unable to generate RSA key: %v", err)
+	}
+
+	signer := jwt.NewSignerKey(opaqueSigner{signer: rsaKey})
+
+	method := jwt.GetSigningMethod("RS256")
+	toSign := "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.eyJmb28iOiJiYXIifQ"
+
+	sig, err := method.Sign(toSign, signer)
+	if err != nil {
+		t.Fatalf("Error signing with opaque crypto.Signer: %v", err)
+	}
+
+	if err := method.Verify(toSign, sig, &rsaKey.PublicKey); err != nil {
+		t.Errorf("Signature produced via crypto.Signer failed to verify: %v", err)
+	}
+}
+
+func TestSignerKeyEd25519RoundTrip(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate Ed25519 key: %v", err)
+	}
+
+	signer := jwt.NewSignerKey(opaqueSigner{signer: privKey})
+
+	method := jwt.GetSigningMethod("EdDSA")
+	toSign := "eyJhbGciOiJFZERTQSJ9.eyJmb28iOiJiYXIifQ"
+
+	sig, err := method.Sign(toSign, signer)
+	if err != nil {
+		t.Fatalf("Error signing with opaque crypto.Signer: %v", err)
+	}
+
+	if err := method.Verify(toSign, sig, pubKey); err != nil {
+		t.Errorf("Signature produced via crypto.Signer failed to verify: %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package jwt
+
+import "sync"
+
+var signingMethods = map[string]func() SigningMethod{}
+var signingMethodLock = new(sync.RWMutex)
+
+// SigningMethod can be used to add new methods for signing or verifying
+// tokens. It takes a decoded signature as sig and the signing string that
+// produced it (the base64url-encoded header and payload, joined by a '.';
+// with "b64": false the payload is included unencoded) as signingString.
+type SigningMethod interface {
+	Verify(signingString string, sig []byte, key interface{}) error // Returns nil if signature is valid
+	Sign(signingString string, key interface{}) ([]byte, error)     // Returns raw (not base64url-encoded) signature or error
+	Alg() string                                                    // Returns the alg identifier for this method (example: "ES256")
+}
+
+// RegisterSigningMethod registers the "alg" name and a factory function for
+// a signing method. This is typically done in the method implementation's
+// init() function.
+func RegisterSigningMethod(alg string, f func() SigningMethod) {
+	signingMethodLock.Lock()
+	defer signingMethodLock.Unlock()
+
+	signingMethods[alg] = f
+}
+
+// GetSigningMethod retrieves a signing method previously registered under
+// the given "alg" name, or nil if none is registered.
+func GetSigningMethod(alg string) (method SigningMethod) {
+	signingMethodLock.RLock()
+	defer signingMethodLock.RUnlock()
+
+	if methodF, ok := signingMethods[alg]; ok {
+		method = methodF()
+	}
+	return
+}
@@ -0,0 +1,121 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Keyfunc is used by the Parse methods as a callback to supply the key for
+// verification. It receives the parsed, but not yet verified, Token, so
+// that properties of its Header (such as "kid") can be used to select
+// which key to use.
+type Keyfunc func(*Token) (interface{}, error)
+
+// Token represents a JWT. Different fields are populated depending on
+// whether the token is being created or was parsed from a string.
+type Token struct {
+	Raw       string                 // Raw contains the raw token, populated when the token was parsed from a string
+	Method    SigningMethod          // Method is the signing method used or detected for this token
+	Header    map[string]interface{} // Header is the first segment of the token in decoded form
+	Claims    interface{}            // Claims is the second segment of the token in decoded form
+	Signature string                 // Signature is the third segment of the token, populated when the token was parsed from a string
+	Valid     bool                   // Valid specifies whether the token has passed validation
+
+	// unencodedPayload overrides the payload derived from Claims when
+	// signing. It is set by NewWithUnencodedPayload for RFC 7797
+	// (b64=false) tokens, whose payload is carried as-is rather than
+	// being the JSON encoding of a claims value.
+	unencodedPayload []byte
+}
+
+// New creates a new Token with the specified signing method and an empty map of claims.
+func New(method SigningMethod) *Token {
+	return NewWithClaims(method, make(map[string]interface{}))
+}
+
+// NewWithClaims creates a new Token with the specified signing method and claims.
+func NewWithClaims(method SigningMethod, claims interface{}) *Token {
+	return &Token{
+		Header: map[string]interface{}{
+			"typ": "JWT",
+			"alg": method.Alg(),
+		},
+		Claims: claims,
+		Method: method,
+	}
+}
+
+// NewWithUnencodedPayload creates a new Token whose payload is the literal
+// bytes in payload rather than the JSON encoding of a claims value, per RFC
+// 7797. The returned token's Header already carries "b64": false and
+// "crit": ["b64"], as required by the RFC.
+func NewWithUnencodedPayload(method SigningMethod, payload []byte) *Token {
+	return &Token{
+		Header: map[string]interface{}{
+			"typ":  "JWT",
+			"alg":  method.Alg(),
+			"b64":  false,
+			"crit": []interface{}{"b64"},
+		},
+		unencodedPayload: payload,
+		Method:           method,
+	}
+}
+
+// SignedString creates and returns a complete, signed JWT in compact
+// serialization.
+func (t *Token) SignedString(key interface{}) (string, error) {
+	sstr, err := t.SigningString()
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := t.Method.Sign(sstr, key)
+	if err != nil {
+		return "", err
+	}
+
+	return sstr + "." + t.EncodeSegment(sig), nil
+}
+
+// SigningString generates the signing string for the token: the
+// base64url-encoded header, a period, and the payload segment. The payload
+// segment is base64url-encoded unless the header sets "b64": false (RFC
+// 7797), in which case the payload is included as-is.
+func (t *Token) SigningString() (string, error) {
+	h, err := json.Marshal(t.Header)
+	if err != nil {
+		return "", err
+	}
+	headerSeg := t.EncodeSegment(h)
+
+	payload, err := t.payloadBytes()
+	if err != nil {
+		return "", err
+	}
+
+	if t.unencoded() {
+		return headerSeg + "." + string(payload), nil
+	}
+
+	return headerSeg + "." + t.EncodeSegment(payload), nil
+}
+
+func (t *Token) payloadBytes() ([]byte, error) {
+	if t.unencodedPayload != nil {
+		return t.unencodedPayload, nil
+	}
+
+	return json.Marshal(t.Claims)
+}
+
+func (t *Token) unencoded() bool {
+	b64, ok := t.Header["b64"].(bool)
+	return ok && !b64
+}
+
+// EncodeSegment encodes a JWT segment as base64url without padding, per RFC
+// 7515 §2.
+func (t *Token) EncodeSegment(seg []byte) string {
+	return base64.RawURLEncoding.EncodeToString(seg)
+}